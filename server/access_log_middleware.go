@@ -0,0 +1,29 @@
+package server
+
+import "time"
+
+// AccessLogMiddleware returns a Middleware that logs one line per request
+// through logger: method, path, status code, response size, duration, and
+// the client's remote address.
+func AccessLogMiddleware(logger Logger) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(req Request, resp Response) {
+			start := time.Now()
+			next(req, resp)
+			duration := time.Since(start)
+
+			var status, size int
+			if r, ok := unwrapResponse(resp); ok {
+				status = r.statusCode
+				if r.headerSent {
+					size = r.written
+				} else {
+					size = len(r.body)
+				}
+			}
+
+			logger.Printf("%s %s %d %dB %s %s",
+				req.Method(), req.Path(), status, size, duration, req.RemoteAddr())
+		}
+	}
+}
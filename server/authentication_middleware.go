@@ -0,0 +1,33 @@
+package server
+
+import "net/http"
+
+// AuthenticationMiddleware returns a Middleware that authenticates each
+// request with a, writing the appropriate WWW-Authenticate challenges and
+// a 401 Unauthorized when denied. Prefer Server.SetAuthenticator for the
+// common case; use this instead via Use when you need explicit control
+// over where authentication runs relative to other middleware, e.g. so an
+// AccessLogMiddleware registered before it also observes denied requests.
+func AuthenticationMiddleware(a Authenticator) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(req Request, resp Response) {
+			r, ok := req.(*request)
+			if !ok {
+				resp.SetStatusCode(http.StatusUnauthorized)
+				return
+			}
+
+			result := a.Authenticate(r.httpReq)
+			if !result.Allowed {
+				for _, challenge := range result.Challenges {
+					resp.SetHeader("WWW-Authenticate", challenge.String())
+				}
+				resp.SetStatusCode(http.StatusUnauthorized)
+				return
+			}
+
+			r.principal = result.Principal
+			next(req, resp)
+		}
+	}
+}
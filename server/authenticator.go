@@ -0,0 +1,91 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+//go:generate counterfeiter . Authenticator
+
+// Principal identifies the party that made an authenticated request.
+type Principal interface {
+	Name() string
+}
+
+// Challenge describes a WWW-Authenticate challenge to send back to the
+// client when authentication is denied, modeled on the Docker/OCI registry
+// scheme (RFC 7235 section 4.1).
+type Challenge struct {
+	Scheme string
+	Params map[string]string
+}
+
+// String renders the challenge as a WWW-Authenticate header value, with
+// params sorted by name, e.g.
+// `Bearer realm="os-agent",scope="repository:foo:pull",service="os-agent"`.
+func (c Challenge) String() string {
+	if len(c.Params) == 0 {
+		return c.Scheme
+	}
+
+	names := make([]string, 0, len(c.Params))
+	for name := range c.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, name, escapeQuoted(c.Params[name])))
+	}
+	return c.Scheme + " " + strings.Join(parts, ",")
+}
+
+func escapeQuoted(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return s
+}
+
+// AuthResult is the outcome of an authentication attempt.
+type AuthResult struct {
+	// Allowed reports whether the request may proceed.
+	Allowed bool
+	// Principal identifies the caller. Only set when Allowed is true.
+	Principal Principal
+	// Challenges are sent back as WWW-Authenticate headers when Allowed is
+	// false. An Authenticator may contribute zero, one, or several.
+	Challenges []Challenge
+}
+
+// Authenticator decides whether an incoming HTTP request may proceed.
+type Authenticator interface {
+	Authenticate(r *http.Request) AuthResult
+}
+
+type basicPrincipal string
+
+func (p basicPrincipal) Name() string { return string(p) }
+
+type simpleAuthenticator struct {
+	authFunc func(username, password string) bool
+	realm    string
+}
+
+// NewSimpleAuthenticator returns an Authenticator that validates HTTP Basic
+// credentials using authFunc.
+func NewSimpleAuthenticator(authFunc func(username, password string) bool) Authenticator {
+	return &simpleAuthenticator{authFunc: authFunc, realm: "os-agent"}
+}
+
+func (a *simpleAuthenticator) Authenticate(r *http.Request) AuthResult {
+	username, password, ok := r.BasicAuth()
+	if !ok || !a.authFunc(username, password) {
+		return AuthResult{Challenges: []Challenge{
+			{Scheme: "Basic", Params: map[string]string{"realm": a.realm}},
+		}}
+	}
+	return AuthResult{Allowed: true, Principal: basicPrincipal(username)}
+}
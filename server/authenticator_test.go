@@ -0,0 +1,123 @@
+package server_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	. "github.com/Bo0mer/os-agent/server"
+	"github.com/Bo0mer/os-agent/server/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Authenticators", func() {
+
+	var server Server
+	var handler *fakes.FakeHandler
+
+	var doGetWithHeader = func(path, header, value string) (int, []string) {
+		url := fmt.Sprintf("%s%s", server.Address(), path)
+		req, _ := http.NewRequest("GET", url, nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		return resp.StatusCode, resp.Header["Www-Authenticate"]
+	}
+
+	BeforeEach(func() {
+		server = NewServer("127.0.0.1", 0)
+		Expect(server.Start()).To(Succeed())
+
+		handler = new(fakes.FakeHandler)
+		handler.BindingReturns(Binding{Method: "GET", Path: "/protected"})
+		handler.HandleStub = func(req Request, resp Response) {
+			resp.SetStatusCode(http.StatusOK)
+		}
+		server.Register(handler)
+	})
+
+	AfterEach(func() {
+		server.Stop()
+	})
+
+	Describe("BearerAuthenticator", func() {
+		BeforeEach(func() {
+			validator := func(token string) (Claims, error) {
+				if token != "valid-token" {
+					return nil, errors.New("invalid token")
+				}
+				return NewClaims("ivan"), nil
+			}
+			server.SetAuthenticator(NewBearerAuthenticator(validator))
+		})
+
+		It("allows requests bearing a valid token", func() {
+			status, _ := doGetWithHeader("/protected", "Authorization", "Bearer valid-token")
+			Expect(status).To(Equal(http.StatusOK))
+		})
+
+		It("exposes the validated claims as the request's principal", func() {
+			handler.HandleStub = func(req Request, resp Response) {
+				Expect(req.Principal()).ToNot(BeNil())
+				Expect(req.Principal().Name()).To(Equal("ivan"))
+				resp.SetStatusCode(http.StatusOK)
+			}
+
+			status, _ := doGetWithHeader("/protected", "Authorization", "Bearer valid-token")
+			Expect(status).To(Equal(http.StatusOK))
+		})
+
+		It("denies requests with no Authorization header and challenges for Bearer", func() {
+			status, challenges := doGetWithHeader("/protected", "", "")
+			Expect(status).To(Equal(http.StatusUnauthorized))
+			Expect(challenges).To(ConsistOf(ContainSubstring(`Bearer realm="os-agent"`)))
+		})
+
+		It("denies requests with a mismatched scheme", func() {
+			status, challenges := doGetWithHeader("/protected", "Authorization", "Basic dXNlcjpwYXNz")
+			Expect(status).To(Equal(http.StatusUnauthorized))
+			Expect(challenges).To(ConsistOf(ContainSubstring("Bearer")))
+		})
+
+		It("denies requests carrying an invalid token", func() {
+			status, _ := doGetWithHeader("/protected", "Authorization", "Bearer garbage")
+			Expect(status).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Describe("ChainAuthenticator", func() {
+		BeforeEach(func() {
+			basic := NewSimpleAuthenticator(func(username, password string) bool {
+				return username == "ivan" && password == "secret"
+			})
+			bearer := NewBearerAuthenticator(func(token string) (Claims, error) {
+				if token != "valid-token" {
+					return nil, errors.New("invalid token")
+				}
+				return NewClaims("ivan"), nil
+			})
+			server.SetAuthenticator(NewChainAuthenticator(basic, bearer))
+		})
+
+		It("allows requests authenticated via the second authenticator in the chain", func() {
+			status, _ := doGetWithHeader("/protected", "Authorization", "Bearer valid-token")
+			Expect(status).To(Equal(http.StatusOK))
+		})
+
+		It("combines every authenticator's challenge when all of them deny", func() {
+			status, challenges := doGetWithHeader("/protected", "", "")
+			Expect(status).To(Equal(http.StatusUnauthorized))
+			Expect(challenges).To(ConsistOf(
+				ContainSubstring("Basic"),
+				ContainSubstring("Bearer"),
+			))
+		})
+	})
+})
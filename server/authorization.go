@@ -0,0 +1,115 @@
+package server
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// errMalformedAuthorization is returned by parseAuthorization when the
+// header does not conform to RFC 7235 section 2.1.
+var errMalformedAuthorization = errors.New("server: malformed Authorization header")
+
+// token68Pattern matches the token68 production of RFC 7235 section 2.1:
+// `1*( ALPHA / DIGIT / "-" / "." / "_" / "~" / "+" / "/" ) *"="`. Trailing
+// "=" is base64 padding, not the start of an auth-param list, so a string
+// like "dXNlcjpwYXNzd29yZA==" must still be recognized as a token68
+// rather than mistaken for "name=value" pairs.
+var token68Pattern = regexp.MustCompile(`^[A-Za-z0-9\-._~+/]+=*$`)
+
+// authParams holds the credentials portion of an Authorization header:
+// either a single token68 value (as used by "Bearer <jwt>" and
+// "Basic <base64>") or a set of quoted auth-param pairs.
+type authParams struct {
+	token68 string
+	params  map[string]string
+}
+
+// parseAuthorization parses the value of an Authorization header per RFC
+// 7235 section 2.1: `scheme 1*SP ( token68 / #auth-param )`.
+func parseAuthorization(header string) (scheme string, params authParams, err error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return "", authParams{}, errMalformedAuthorization
+	}
+
+	sp := strings.IndexByte(header, ' ')
+	if sp < 0 {
+		return "", authParams{}, errMalformedAuthorization
+	}
+
+	scheme = header[:sp]
+	rest := strings.TrimSpace(header[sp+1:])
+	if rest == "" {
+		return "", authParams{}, errMalformedAuthorization
+	}
+
+	if token68Pattern.MatchString(rest) {
+		return scheme, authParams{token68: rest}, nil
+	}
+
+	parsed, err := parseAuthParamList(rest)
+	if err != nil {
+		return "", authParams{}, err
+	}
+	return scheme, authParams{params: parsed}, nil
+}
+
+// parseAuthParamList parses a comma-separated list of auth-params, each
+// either `name=token` or `name="quoted value with \" escapes"`.
+func parseAuthParamList(s string) (map[string]string, error) {
+	params := make(map[string]string)
+
+	for len(s) > 0 {
+		s = strings.TrimSpace(s)
+
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			return nil, errMalformedAuthorization
+		}
+		name := strings.TrimSpace(s[:eq])
+		s = strings.TrimSpace(s[eq+1:])
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			var err error
+			value, s, err = parseQuotedString(s)
+			if err != nil {
+				return nil, err
+			}
+		} else if comma := strings.IndexByte(s, ','); comma >= 0 {
+			value, s = s[:comma], s[comma+1:]
+		} else {
+			value, s = s, ""
+		}
+		params[name] = strings.TrimSpace(value)
+
+		s = strings.TrimSpace(s)
+		s = strings.TrimPrefix(s, ",")
+	}
+
+	return params, nil
+}
+
+// parseQuotedString parses a double-quoted string starting at s[0] == '"',
+// honoring backslash escapes (RFC 7230 section 3.2.6), and returns the
+// unescaped value together with whatever remains of s after the closing
+// quote.
+func parseQuotedString(s string) (value, rest string, err error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 >= len(s) {
+				return "", "", errMalformedAuthorization
+			}
+			b.WriteByte(s[i+1])
+			i++
+		case '"':
+			return b.String(), s[i+1:], nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", errMalformedAuthorization
+}
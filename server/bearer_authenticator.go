@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Claims are the verified contents of a bearer token, exposed to handlers
+// as the request's Principal.
+type Claims interface {
+	Principal
+}
+
+type claims struct {
+	name string
+}
+
+// NewClaims builds a Claims value carrying the given principal name. It is
+// a convenience for validators that don't need a richer Claims type.
+func NewClaims(name string) Claims {
+	return claims{name: name}
+}
+
+func (c claims) Name() string { return c.name }
+
+type bearerAuthenticator struct {
+	validator func(token string) (Claims, error)
+	realm     string
+	service   string
+	scope     string
+}
+
+// BearerOption configures the Bearer challenge a bearerAuthenticator sends
+// back when it denies a request.
+type BearerOption func(*bearerAuthenticator)
+
+// WithRealm sets the realm reported in the Bearer challenge. Defaults to
+// "os-agent".
+func WithRealm(realm string) BearerOption {
+	return func(a *bearerAuthenticator) { a.realm = realm }
+}
+
+// WithService sets the service reported in the Bearer challenge, as used
+// by the Docker/OCI registry token scheme. Omitted when unset.
+func WithService(service string) BearerOption {
+	return func(a *bearerAuthenticator) { a.service = service }
+}
+
+// WithScope sets the scope reported in the Bearer challenge, as used by
+// the Docker/OCI registry token scheme. Omitted when unset.
+func WithScope(scope string) BearerOption {
+	return func(a *bearerAuthenticator) { a.scope = scope }
+}
+
+// NewBearerAuthenticator returns an Authenticator that validates
+// `Authorization: Bearer <token>` headers using validator. On success, the
+// Claims returned by validator become the request's Principal.
+func NewBearerAuthenticator(validator func(token string) (Claims, error), opts ...BearerOption) Authenticator {
+	a := &bearerAuthenticator{validator: validator, realm: "os-agent"}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func (a *bearerAuthenticator) Authenticate(r *http.Request) AuthResult {
+	scheme, params, err := parseAuthorization(r.Header.Get("Authorization"))
+	if err != nil || !strings.EqualFold(scheme, "Bearer") || params.token68 == "" {
+		return AuthResult{Challenges: []Challenge{a.challenge()}}
+	}
+
+	claims, err := a.validator(params.token68)
+	if err != nil {
+		return AuthResult{Challenges: []Challenge{a.challenge()}}
+	}
+	return AuthResult{Allowed: true, Principal: claims}
+}
+
+func (a *bearerAuthenticator) challenge() Challenge {
+	params := map[string]string{"realm": a.realm}
+	if a.service != "" {
+		params["service"] = a.service
+	}
+	if a.scope != "" {
+		params["scope"] = a.scope
+	}
+	return Challenge{Scheme: "Bearer", Params: params}
+}
@@ -0,0 +1,143 @@
+package server_test
+
+import (
+	"net/http"
+
+	. "github.com/Bo0mer/os-agent/server"
+	"github.com/Bo0mer/os-agent/server/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Path parameters", func() {
+
+	var server Server
+
+	BeforeEach(func() {
+		server = NewServer("127.0.0.1", 0)
+		Expect(server.Start()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		server.Stop()
+	})
+
+	It("extracts named path parameters and exposes them via PathValue", func() {
+		handler := new(fakes.FakeHandler)
+		handler.BindingReturns(Binding{Method: "GET", Path: "/jobs/{id}/logs/{stream}"})
+		handler.HandleStub = func(req Request, resp Response) {
+			Expect(req.PathValue("id")).To(Equal("42"))
+			Expect(req.PathValue("stream")).To(Equal("stdout"))
+			Expect(req.PathValues()).To(Equal(map[string]string{"id": "42", "stream": "stdout"}))
+			resp.SetStatusCode(http.StatusOK)
+		}
+		server.Register(handler)
+
+		resp, err := http.Get(address(server) + "/jobs/42/logs/stdout")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	Context("when a static route and a parameterized route overlap", func() {
+		BeforeEach(func() {
+			staticHandler := new(fakes.FakeHandler)
+			staticHandler.BindingReturns(Binding{Method: "GET", Path: "/jobs/current"})
+			staticHandler.HandleStub = func(req Request, resp Response) {
+				resp.SetStatusCode(http.StatusOK)
+				resp.SetBody([]byte("static"))
+			}
+			server.Register(staticHandler)
+
+			paramHandler := new(fakes.FakeHandler)
+			paramHandler.BindingReturns(Binding{Method: "GET", Path: "/jobs/{id}"})
+			paramHandler.HandleStub = func(req Request, resp Response) {
+				resp.SetStatusCode(http.StatusOK)
+				resp.SetBody([]byte("param:" + req.PathValue("id")))
+			}
+			server.Register(paramHandler)
+		})
+
+		It("prefers the longest static prefix", func() {
+			resp, err := http.Get(address(server) + "/jobs/current")
+			Expect(err).ToNot(HaveOccurred())
+			body, _ := readAll(resp)
+			Expect(string(body)).To(Equal("static"))
+		})
+
+		It("falls back to the parameterized route otherwise", func() {
+			resp, err := http.Get(address(server) + "/jobs/17")
+			Expect(err).ToNot(HaveOccurred())
+			body, _ := readAll(resp)
+			Expect(string(body)).To(Equal("param:17"))
+		})
+	})
+
+	Context("when the most specific overlapping route doesn't support the request's method", func() {
+		BeforeEach(func() {
+			staticHandler := new(fakes.FakeHandler)
+			staticHandler.BindingReturns(Binding{Method: "POST", Path: "/jobs/current"})
+			staticHandler.HandleStub = func(req Request, resp Response) {
+				resp.SetStatusCode(http.StatusOK)
+				resp.SetBody([]byte("static"))
+			}
+			server.Register(staticHandler)
+
+			paramHandler := new(fakes.FakeHandler)
+			paramHandler.BindingReturns(Binding{Method: "GET", Path: "/jobs/{id}"})
+			paramHandler.HandleStub = func(req Request, resp Response) {
+				resp.SetStatusCode(http.StatusOK)
+				resp.SetBody([]byte("param:" + req.PathValue("id")))
+			}
+			server.Register(paramHandler)
+		})
+
+		It("falls back to the less specific route that does support the method", func() {
+			resp, err := http.Get(address(server) + "/jobs/current")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			body, _ := readAll(resp)
+			Expect(string(body)).To(Equal("param:current"))
+		})
+	})
+
+	Context("when method not allowed reporting is disabled (the default)", func() {
+		BeforeEach(func() {
+			handler := new(fakes.FakeHandler)
+			handler.BindingReturns(Binding{Method: "POST", Path: "/jobs/{id}"})
+			server.Register(handler)
+		})
+
+		It("returns 404 for a path match with a different method", func() {
+			resp, err := http.Get(address(server) + "/jobs/1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when method not allowed reporting is enabled", func() {
+		BeforeEach(func() {
+			server.Stop()
+			server = NewServer("127.0.0.1", 0, WithMethodNotAllowed())
+			Expect(server.Start()).To(Succeed())
+
+			handler := new(fakes.FakeHandler)
+			handler.BindingReturns(Binding{Method: "POST", Path: "/jobs/{id}"})
+			server.Register(handler)
+
+			other := new(fakes.FakeHandler)
+			other.BindingReturns(Binding{Method: "DELETE", Path: "/jobs/{id}"})
+			server.Register(other)
+		})
+
+		It("returns 405 with an Allow header listing the registered methods", func() {
+			resp, err := http.Get(address(server) + "/jobs/1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+			Expect(resp.Header.Get("Allow")).To(SatisfyAll(
+				ContainSubstring("DELETE"),
+				ContainSubstring("POST"),
+			))
+		})
+	})
+})
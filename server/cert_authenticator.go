@@ -0,0 +1,33 @@
+package server
+
+import (
+	"crypto/x509/pkix"
+	"net/http"
+)
+
+type certPrincipal struct {
+	subject pkix.Name
+}
+
+func (p certPrincipal) Name() string { return p.subject.CommonName }
+
+type certAuthenticator struct{}
+
+// NewCertAuthenticator returns an Authenticator that allows any request
+// carrying a TLS client certificate the server already verified (see
+// ServerOptions.ClientCAs), exposing the certificate's subject as the
+// request's Principal. Compose it with NewChainAuthenticator to let a
+// handler accept either a valid client certificate or another scheme,
+// e.g. Basic auth.
+func NewCertAuthenticator() Authenticator {
+	return certAuthenticator{}
+}
+
+func (certAuthenticator) Authenticate(r *http.Request) AuthResult {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return AuthResult{Challenges: []Challenge{{Scheme: "Mutual"}}}
+	}
+
+	subject := r.TLS.VerifiedChains[0][0].Subject
+	return AuthResult{Allowed: true, Principal: certPrincipal{subject: subject}}
+}
@@ -0,0 +1,28 @@
+package server
+
+import "net/http"
+
+type chainAuthenticator struct {
+	authenticators []Authenticator
+}
+
+// NewChainAuthenticator returns an Authenticator that tries each of
+// authenticators in order and allows the request as soon as one of them
+// does. If all of them deny, the challenges they each contributed are
+// combined, so a client sees every scheme it could have used to
+// authenticate.
+func NewChainAuthenticator(authenticators ...Authenticator) Authenticator {
+	return &chainAuthenticator{authenticators: authenticators}
+}
+
+func (a *chainAuthenticator) Authenticate(r *http.Request) AuthResult {
+	var challenges []Challenge
+	for _, authenticator := range a.authenticators {
+		result := authenticator.Authenticate(r)
+		if result.Allowed {
+			return result
+		}
+		challenges = append(challenges, result.Challenges...)
+	}
+	return AuthResult{Challenges: challenges}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+)
+
+// GzipMiddleware returns a Middleware that compresses the response body
+// with gzip when the client's Accept-Encoding header allows it, setting
+// Content-Encoding accordingly. It covers both buffered responses (set via
+// SetBody) and streamed ones (written through BodyWriter).
+func GzipMiddleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(req Request, resp Response) {
+			r, ok := resp.(*response)
+			if !ok || !acceptsGzip(req) {
+				next(req, resp)
+				return
+			}
+
+			next(req, &gzipResponse{Response: resp})
+
+			if r.headerSent || len(r.body) == 0 {
+				return
+			}
+
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			if _, err := gz.Write(r.body); err != nil {
+				return
+			}
+			if err := gz.Close(); err != nil {
+				return
+			}
+
+			r.SetHeader("Content-Encoding", "gzip")
+			r.SetBody(buf.Bytes())
+		}
+	}
+}
+
+func acceptsGzip(req Request) bool {
+	for _, encoding := range strings.Split(req.Header("Accept-Encoding"), ",") {
+		if strings.TrimSpace(encoding) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipResponse wraps a Response so that a streamed body, not just a
+// buffered one, is transparently gzip-encoded: BodyWriter sets
+// Content-Encoding before headers flush and interposes a gzip.Writer
+// between the handler and the underlying stream.
+type gzipResponse struct {
+	Response
+}
+
+// Unwrap exposes the wrapped Response so other middleware (e.g.
+// AccessLogMiddleware) can see through this decorator.
+func (g *gzipResponse) Unwrap() Response {
+	return g.Response
+}
+
+func (g *gzipResponse) SetStreaming(streaming bool) {
+	if streaming {
+		g.Response.SetHeader("Content-Encoding", "gzip")
+	}
+	g.Response.SetStreaming(streaming)
+}
+
+func (g *gzipResponse) BodyWriter() io.WriteCloser {
+	g.Response.SetHeader("Content-Encoding", "gzip")
+	underlying := g.Response.BodyWriter()
+	return &gzipWriteCloser{gz: gzip.NewWriter(underlying), underlying: underlying}
+}
+
+// gzipWriteCloser closes the gzip.Writer (flushing its trailer) before
+// closing the underlying stream.
+type gzipWriteCloser struct {
+	gz         *gzip.Writer
+	underlying io.WriteCloser
+}
+
+func (g *gzipWriteCloser) Write(p []byte) (int, error) {
+	return g.gz.Write(p)
+}
+
+func (g *gzipWriteCloser) Close() error {
+	if err := g.gz.Close(); err != nil {
+		return err
+	}
+	return g.underlying.Close()
+}
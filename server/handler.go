@@ -0,0 +1,19 @@
+package server
+
+//go:generate counterfeiter . Handler
+
+// Binding describes the HTTP method and path a Handler wants to be
+// registered under.
+type Binding struct {
+	Method string
+	Path   string
+}
+
+// Handler serves requests for a single Binding.
+type Handler interface {
+	// Binding returns the method and path this handler should be
+	// registered under.
+	Binding() Binding
+	// Handle serves req, writing the result to resp.
+	Handle(req Request, resp Response)
+}
@@ -0,0 +1,17 @@
+package server_test
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/Bo0mer/os-agent/server"
+)
+
+func address(server Server) string {
+	return server.Address()
+}
+
+func readAll(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
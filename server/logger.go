@@ -0,0 +1,7 @@
+package server
+
+// Logger is the minimal logging interface required by the built-in
+// middleware. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
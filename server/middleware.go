@@ -0,0 +1,20 @@
+package server
+
+// HandlerFunc adapts a plain function to the calling convention a
+// Middleware operates on.
+type HandlerFunc func(req Request, resp Response)
+
+// Middleware wraps a HandlerFunc, letting it observe or mutate the
+// Request/Response around the call, short-circuit by not calling next, or
+// recover from a panic raised by it.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// chain composes middleware around final in registration order, so the
+// first Middleware passed to Use is the outermost one: it runs first and
+// returns last.
+func chain(final HandlerFunc, middleware []Middleware) HandlerFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		final = middleware[i](final)
+	}
+	return final
+}
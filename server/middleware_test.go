@@ -0,0 +1,212 @@
+package server_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"log"
+	"net/http"
+
+	. "github.com/Bo0mer/os-agent/server"
+	"github.com/Bo0mer/os-agent/server/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Middleware", func() {
+
+	var server Server
+	var handler *fakes.FakeHandler
+
+	BeforeEach(func() {
+		server = NewServer("127.0.0.1", 0)
+
+		handler = new(fakes.FakeHandler)
+		handler.BindingReturns(Binding{Method: "GET", Path: "/foo"})
+	})
+
+	AfterEach(func() {
+		server.Stop()
+	})
+
+	It("invokes middleware in registration order around the handler", func() {
+		var trace []string
+
+		trace = append(trace, "setup")
+		recordingMiddleware := func(name string) Middleware {
+			return func(next HandlerFunc) HandlerFunc {
+				return func(req Request, resp Response) {
+					trace = append(trace, name+":before")
+					next(req, resp)
+					trace = append(trace, name+":after")
+				}
+			}
+		}
+
+		Expect(server.Use(recordingMiddleware("outer"))).To(Succeed())
+		Expect(server.Use(recordingMiddleware("inner"))).To(Succeed())
+
+		handler.HandleStub = func(req Request, resp Response) {
+			trace = append(trace, "handle")
+			resp.SetStatusCode(http.StatusOK)
+		}
+		server.Register(handler)
+
+		Expect(server.Start()).To(Succeed())
+
+		resp, err := http.Get(address(server) + "/foo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		Expect(trace).To(Equal([]string{
+			"setup",
+			"outer:before", "inner:before", "handle", "inner:after", "outer:after",
+		}))
+	})
+
+	It("returns an error when middleware is added after the server has started", func() {
+		Expect(server.Start()).To(Succeed())
+
+		err := server.Use(func(next HandlerFunc) HandlerFunc { return next })
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("RecoverMiddleware", func() {
+		BeforeEach(func() {
+			var logBuf bytes.Buffer
+			logger := log.New(&logBuf, "", 0)
+
+			Expect(server.Use(RecoverMiddleware(logger))).To(Succeed())
+		})
+
+		It("turns a panicking handler into a 500 instead of crashing the server", func() {
+			handler.HandleStub = func(req Request, resp Response) {
+				panic("boom")
+			}
+			server.Register(handler)
+			Expect(server.Start()).To(Succeed())
+
+			resp, err := http.Get(address(server) + "/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("isolates the panic to the failing request, leaving the server serving", func() {
+			calls := 0
+			handler.HandleStub = func(req Request, resp Response) {
+				calls++
+				if calls == 1 {
+					panic("boom")
+				}
+				resp.SetStatusCode(http.StatusOK)
+			}
+			server.Register(handler)
+			Expect(server.Start()).To(Succeed())
+
+			resp, err := http.Get(address(server) + "/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+
+			resp, err = http.Get(address(server) + "/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+	})
+
+	Describe("GzipMiddleware", func() {
+		BeforeEach(func() {
+			Expect(server.Use(GzipMiddleware())).To(Succeed())
+
+			handler.HandleStub = func(req Request, resp Response) {
+				resp.SetStatusCode(http.StatusOK)
+				resp.SetBody([]byte("hello, gzip"))
+			}
+			server.Register(handler)
+			Expect(server.Start()).To(Succeed())
+		})
+
+		It("compresses the body when the client accepts gzip", func() {
+			req, _ := http.NewRequest("GET", address(server)+"/foo", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Header.Get("Content-Encoding")).To(Equal("gzip"))
+		})
+
+		It("leaves the body uncompressed when the client does not accept gzip", func() {
+			client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+			resp, err := client.Get(address(server) + "/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Header.Get("Content-Encoding")).To(BeEmpty())
+
+			body, _ := readAll(resp)
+			Expect(string(body)).To(Equal("hello, gzip"))
+		})
+
+		It("compresses a streamed response written through BodyWriter", func() {
+			handler.HandleStub = func(req Request, resp Response) {
+				resp.SetStreaming(true)
+				w := resp.BodyWriter()
+				w.Write([]byte("hello, streamed gzip"))
+				w.Close()
+			}
+
+			req, _ := http.NewRequest("GET", address(server)+"/foo", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Header.Get("Content-Encoding")).To(Equal("gzip"))
+
+			gz, err := gzip.NewReader(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			body, _ := readAll(&http.Response{Body: gz})
+			Expect(string(body)).To(Equal("hello, streamed gzip"))
+		})
+	})
+
+	Describe("AccessLogMiddleware", func() {
+		It("logs one line per request", func() {
+			var logBuf bytes.Buffer
+			logger := log.New(&logBuf, "", 0)
+			Expect(server.Use(AccessLogMiddleware(logger))).To(Succeed())
+
+			handler.HandleStub = func(req Request, resp Response) {
+				resp.SetStatusCode(http.StatusOK)
+			}
+			server.Register(handler)
+			Expect(server.Start()).To(Succeed())
+
+			_, err := http.Get(address(server) + "/foo")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(logBuf.String()).To(ContainSubstring("GET"))
+			Expect(logBuf.String()).To(ContainSubstring("/foo"))
+			Expect(logBuf.String()).To(ContainSubstring("200"))
+		})
+
+		It("logs the real status and size even behind GzipMiddleware", func() {
+			var logBuf bytes.Buffer
+			logger := log.New(&logBuf, "", 0)
+			Expect(server.Use(GzipMiddleware())).To(Succeed())
+			Expect(server.Use(AccessLogMiddleware(logger))).To(Succeed())
+
+			handler.HandleStub = func(req Request, resp Response) {
+				resp.SetStatusCode(http.StatusOK)
+				resp.SetBody([]byte("hello world"))
+			}
+			server.Register(handler)
+			Expect(server.Start()).To(Succeed())
+
+			req, _ := http.NewRequest("GET", address(server)+"/foo", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+
+			_, err := http.DefaultClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(logBuf.String()).To(ContainSubstring("200"))
+			Expect(logBuf.String()).ToNot(ContainSubstring(" 0B "))
+		})
+	})
+})
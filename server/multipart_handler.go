@@ -0,0 +1,53 @@
+package server
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// PartFunc processes a single part of a multipart/form-data request.
+type PartFunc func(part *multipart.Part, req Request, resp Response) error
+
+type multipartHandler struct {
+	binding Binding
+	fn      PartFunc
+}
+
+// MultipartHandler returns a Handler, bound to binding, that iterates a
+// multipart/form-data request's parts one at a time via fn without
+// buffering the whole body into memory. Whatever fn leaves unread of a
+// part is discarded when the next part is fetched.
+func MultipartHandler(binding Binding, fn PartFunc) Handler {
+	return multipartHandler{binding: binding, fn: fn}
+}
+
+func (h multipartHandler) Binding() Binding {
+	return h.binding
+}
+
+func (h multipartHandler) Handle(req Request, resp Response) {
+	mr, err := req.MultipartReader()
+	if err != nil {
+		resp.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			resp.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+
+		if err := h.fn(part, req, resp); err != nil {
+			resp.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	resp.SetStatusCode(http.StatusOK)
+}
@@ -0,0 +1,15 @@
+package server
+
+// Option configures optional Server behavior at construction time.
+type Option func(*server)
+
+// WithMethodNotAllowed makes the server respond with 405 Method Not
+// Allowed (and an Allow header listing the registered methods) instead of
+// 404 Not Found when a request's path matches a registered route but its
+// method does not. Off by default, since existing callers may rely on the
+// 404 behavior.
+func WithMethodNotAllowed() Option {
+	return func(s *server) {
+		s.methodNotAllowed = true
+	}
+}
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"net/http"
+	"runtime/debug"
+)
+
+// RecoverMiddleware returns a Middleware that recovers panics raised while
+// handling a request, logs the stack trace via logger, and responds with
+// 500 Internal Server Error instead of crashing the server. Recovery is
+// scoped to a single request; other in-flight requests are unaffected.
+func RecoverMiddleware(logger Logger) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(req Request, resp Response) {
+			defer func() {
+				if err := recover(); err != nil {
+					logger.Printf("server: recovered from panic: %v\n%s", err, debug.Stack())
+					resp.SetStatusCode(http.StatusInternalServerError)
+					resp.SetBody(nil)
+				}
+			}()
+			next(req, resp)
+		}
+	}
+}
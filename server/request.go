@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"sync"
+)
+
+//go:generate counterfeiter . Request
+
+// Request is the handler's view of an incoming HTTP request.
+type Request interface {
+	// Body returns the raw request body, buffering it into memory on
+	// first use. Prefer BodyReader for large or streamed payloads.
+	Body() []byte
+	// BodyReader returns the request body as a stream, without buffering
+	// it. It is mutually exclusive with Body: whichever is called first
+	// consumes the underlying stream.
+	BodyReader() io.ReadCloser
+	// MultipartReader returns a multipart.Reader over a multipart/form-data
+	// body, allowing its parts to be processed one at a time without
+	// buffering. It fails if the request is not multipart/form-data.
+	MultipartReader() (*multipart.Reader, error)
+	// ParamValues returns the query parameter values for name, and whether
+	// the parameter was present at all.
+	ParamValues(name string) ([]string, bool)
+	// Principal returns the identity established by the Server's
+	// Authenticator for this request, or nil if no Authenticator is set.
+	Principal() Principal
+	// PathValue returns the value bound to name by the matched route's
+	// path pattern, e.g. "42" for "{id}" against "/jobs/42". Returns "" if
+	// name was not bound.
+	PathValue(name string) string
+	// PathValues returns every path parameter bound by the matched
+	// route's pattern.
+	PathValues() map[string]string
+	// Method returns the HTTP method of the request.
+	Method() string
+	// Path returns the request's URL path.
+	Path() string
+	// RemoteAddr returns the client's network address.
+	RemoteAddr() string
+	// Header returns the first value associated with the given header
+	// name, or "" if it is absent.
+	Header(name string) string
+	// Context returns the request's context, cancelled once the client
+	// disconnects or the Server begins a Shutdown whose deadline has
+	// passed. Long-running handlers should observe it.
+	Context() context.Context
+}
+
+type request struct {
+	httpReq    *http.Request
+	principal  Principal
+	pathValues map[string]string
+
+	bodyOnce sync.Once
+	body     []byte
+}
+
+func newRequest(r *http.Request, principal Principal, pathValues map[string]string) *request {
+	return &request{httpReq: r, principal: principal, pathValues: pathValues}
+}
+
+func (r *request) Body() []byte {
+	r.bodyOnce.Do(func() {
+		r.body, _ = ioutil.ReadAll(r.httpReq.Body)
+	})
+	return r.body
+}
+
+func (r *request) BodyReader() io.ReadCloser {
+	return r.httpReq.Body
+}
+
+func (r *request) MultipartReader() (*multipart.Reader, error) {
+	return r.httpReq.MultipartReader()
+}
+
+func (r *request) ParamValues(name string) ([]string, bool) {
+	values, ok := r.httpReq.URL.Query()[name]
+	return values, ok
+}
+
+func (r *request) Principal() Principal {
+	return r.principal
+}
+
+func (r *request) PathValue(name string) string {
+	return r.pathValues[name]
+}
+
+func (r *request) PathValues() map[string]string {
+	return r.pathValues
+}
+
+func (r *request) Method() string {
+	return r.httpReq.Method
+}
+
+func (r *request) Path() string {
+	return r.httpReq.URL.Path
+}
+
+func (r *request) RemoteAddr() string {
+	return r.httpReq.RemoteAddr
+}
+
+func (r *request) Header(name string) string {
+	return r.httpReq.Header.Get(name)
+}
+
+func (r *request) Context() context.Context {
+	return r.httpReq.Context()
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"io"
+	"net/http"
+)
+
+//go:generate counterfeiter . Response
+
+// Response is the handler's view of the HTTP response being built.
+type Response interface {
+	// SetBody sets the response body.
+	SetBody(body []byte)
+	// SetStatusCode sets the response status code.
+	SetStatusCode(code int)
+	// SetHeader adds a response header value. Calling it more than once
+	// for the same key (e.g. to send multiple WWW-Authenticate
+	// challenges) appends rather than overwrites.
+	SetHeader(key, value string)
+	// SetStreaming switches the response to streaming mode: the status
+	// code and headers set so far are flushed immediately, and BodyWriter
+	// becomes the only way to write the body. It is a no-op once headers
+	// have already been flushed.
+	SetStreaming(streaming bool)
+	// BodyWriter returns the response body as a stream. Calling it
+	// flushes the status code and headers immediately, as SetStreaming
+	// does; SetBody is ignored from that point on.
+	BodyWriter() io.WriteCloser
+}
+
+// responseUnwrapper is implemented by a Response decorator (such as the
+// one GzipMiddleware installs) that wraps another Response, letting
+// middleware further down the chain see through it to the concrete
+// *response underneath.
+type responseUnwrapper interface {
+	Unwrap() Response
+}
+
+// unwrapResponse follows resp through any responseUnwrapper layers to
+// find the concrete *response underneath, e.g. so AccessLogMiddleware can
+// read the real status code and size even when an earlier middleware
+// (like GzipMiddleware) wrapped resp in a decorator.
+func unwrapResponse(resp Response) (*response, bool) {
+	for {
+		if r, ok := resp.(*response); ok {
+			return r, true
+		}
+		u, ok := resp.(responseUnwrapper)
+		if !ok {
+			return nil, false
+		}
+		resp = u.Unwrap()
+	}
+}
+
+type response struct {
+	w          http.ResponseWriter
+	body       []byte
+	statusCode int
+	header     http.Header
+	headerSent bool
+	// written counts the bytes that have actually reached w: either the
+	// length of the final buffered write, or the running total written
+	// through BodyWriter for a streamed response.
+	written int
+}
+
+func newResponse(w http.ResponseWriter) *response {
+	return &response{w: w, statusCode: http.StatusOK, header: make(http.Header)}
+}
+
+func (r *response) SetBody(body []byte) {
+	r.body = body
+}
+
+func (r *response) SetStatusCode(code int) {
+	if r.headerSent {
+		return
+	}
+	r.statusCode = code
+}
+
+func (r *response) SetHeader(key, value string) {
+	if r.headerSent {
+		return
+	}
+	r.header.Add(key, value)
+}
+
+func (r *response) SetStreaming(streaming bool) {
+	if streaming {
+		r.flushHeaders()
+	}
+}
+
+func (r *response) BodyWriter() io.WriteCloser {
+	r.flushHeaders()
+	return &countingWriteCloser{w: r.w, resp: r}
+}
+
+func (r *response) flushHeaders() {
+	if r.headerSent {
+		return
+	}
+	for key, values := range r.header {
+		for _, value := range values {
+			r.w.Header().Add(key, value)
+		}
+	}
+	r.w.WriteHeader(r.statusCode)
+	r.headerSent = true
+}
+
+// countingWriteCloser adapts an io.Writer that has no meaningful Close
+// (the underlying http.ResponseWriter) to io.WriteCloser, tallying every
+// byte written on resp so streamed responses can be access-logged
+// accurately.
+type countingWriteCloser struct {
+	w    io.Writer
+	resp *response
+}
+
+func (c *countingWriteCloser) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.resp.written += n
+	return n, err
+}
+
+func (c *countingWriteCloser) Close() error { return nil }
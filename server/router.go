@@ -0,0 +1,114 @@
+package server
+
+import "strings"
+
+// patternSegment is one "/"-delimited piece of a compiled route pattern.
+type patternSegment struct {
+	// literal is the exact text to match. Unused for param/catchAll
+	// segments.
+	literal string
+	// param is the name bound to this segment, e.g. "id" for "{id}" or
+	// "rest" for "{rest...}".
+	param string
+	// catchAll marks a trailing "{name...}" segment that consumes the rest
+	// of the path. Only valid as the final segment.
+	catchAll bool
+}
+
+// compiledPattern is a Binding.Path broken into segments so it can be
+// matched against a request path without re-parsing the string each time.
+type compiledPattern struct {
+	raw      string
+	segments []patternSegment
+}
+
+// compilePattern parses a route pattern such as "/jobs/{id}/logs/{stream}"
+// or "/files/{rest...}" into a compiledPattern.
+func compilePattern(path string) compiledPattern {
+	trimmed := strings.Trim(path, "/")
+
+	var parts []string
+	if trimmed != "" {
+		parts = strings.Split(trimmed, "/")
+	}
+
+	segments := make([]patternSegment, 0, len(parts))
+	for _, part := range parts {
+		switch {
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "...}"):
+			segments = append(segments, patternSegment{param: part[1 : len(part)-4], catchAll: true})
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			segments = append(segments, patternSegment{param: part[1 : len(part)-1]})
+		default:
+			segments = append(segments, patternSegment{literal: part})
+		}
+	}
+
+	return compiledPattern{raw: path, segments: segments}
+}
+
+// match reports whether path satisfies the pattern, returning the bound
+// path parameter values on success.
+func (p compiledPattern) match(path string) (params map[string]string, ok bool) {
+	trimmed := strings.Trim(path, "/")
+
+	var parts []string
+	if trimmed != "" {
+		parts = strings.Split(trimmed, "/")
+	}
+
+	params = make(map[string]string)
+	for i, seg := range p.segments {
+		if seg.catchAll {
+			if i >= len(parts) {
+				return nil, false
+			}
+			params[seg.param] = strings.Join(parts[i:], "/")
+			return params, true
+		}
+		if i >= len(parts) {
+			return nil, false
+		}
+		if seg.param != "" {
+			params[seg.param] = parts[i]
+			continue
+		}
+		if seg.literal != parts[i] {
+			return nil, false
+		}
+	}
+
+	if len(p.segments) != len(parts) {
+		return nil, false
+	}
+	return params, true
+}
+
+// specificity ranks a pattern's segments so candidates can be compared:
+// a static segment outranks a parameter segment, which outranks a
+// catch-all, evaluated left to right so the longest static prefix wins.
+func (p compiledPattern) specificity() []int {
+	rank := make([]int, len(p.segments))
+	for i, seg := range p.segments {
+		switch {
+		case seg.catchAll:
+			rank[i] = 0
+		case seg.param != "":
+			rank[i] = 1
+		default:
+			rank[i] = 2
+		}
+	}
+	return rank
+}
+
+// moreSpecific reports whether rank a beats rank b, comparing segment by
+// segment from the start of the path.
+func moreSpecific(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] > b[i]
+		}
+	}
+	return len(a) > len(b)
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("compiledPattern", func() {
+
+	Describe("match", func() {
+		It("extracts named path parameters", func() {
+			pattern := compilePattern("/jobs/{id}/logs/{stream}")
+
+			params, ok := pattern.match("/jobs/42/logs/stdout")
+
+			Expect(ok).To(BeTrue())
+			Expect(params).To(Equal(map[string]string{"id": "42", "stream": "stdout"}))
+		})
+
+		It("rejects a path with a different segment count", func() {
+			pattern := compilePattern("/jobs/{id}")
+
+			_, ok := pattern.match("/jobs/42/logs")
+
+			Expect(ok).To(BeFalse())
+		})
+
+		It("lets a catch-all segment consume the remainder of the path", func() {
+			pattern := compilePattern("/files/{rest...}")
+
+			params, ok := pattern.match("/files/a/b/c.txt")
+
+			Expect(ok).To(BeTrue())
+			Expect(params["rest"]).To(Equal("a/b/c.txt"))
+		})
+
+		It("treats a trailing slash as insignificant", func() {
+			pattern := compilePattern("/jobs/{id}/")
+
+			_, ok := pattern.match("/jobs/42/")
+			Expect(ok).To(BeTrue())
+
+			_, ok = pattern.match("/jobs/42")
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("specificity and moreSpecific", func() {
+		It("prefers the longest static prefix over a parameterized route", func() {
+			static := compilePattern("/jobs/current").specificity()
+			param := compilePattern("/jobs/{id}").specificity()
+
+			Expect(moreSpecific(static, param)).To(BeTrue())
+		})
+
+		It("prefers a param route over a catch-all", func() {
+			param := compilePattern("/files/{name}").specificity()
+			catchAll := compilePattern("/files/{rest...}").specificity()
+
+			Expect(moreSpecific(param, catchAll)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,299 @@
+// Package server provides a small HTTP server built around explicit,
+// testable Handler registrations rather than the standard library's
+// http.ServeMux.
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultShutdownTimeout bounds how long Stop waits for in-flight
+// requests to finish before forcibly closing connections.
+const defaultShutdownTimeout = 5 * time.Second
+
+//go:generate counterfeiter . Server
+
+// Server dispatches registered Handlers over HTTP.
+type Server interface {
+	// Register adds h to the set of handlers the server dispatches to.
+	Register(h Handler)
+	// SetAuthenticator installs the Authenticator used to guard every
+	// registered route. Passing nil disables authentication. It runs
+	// before routing and before any Use middleware; use
+	// AuthenticationMiddleware with Use instead if you need it ordered
+	// relative to other middleware.
+	SetAuthenticator(a Authenticator)
+	// Use adds mw to the middleware pipeline every matched Handler is
+	// invoked through, in registration order. It returns an error if the
+	// server has already started, since the pipeline is fixed at Start.
+	Use(mw Middleware) error
+	// Start begins listening. Calling Start on an already started server
+	// does nothing.
+	Start() error
+	// Stop stops listening. Calling Stop on an already stopped server does
+	// nothing. It is a thin wrapper around Shutdown with a default timeout.
+	Stop() error
+	// Shutdown stops accepting new connections and waits for in-flight
+	// requests to finish before returning. It returns ctx.Err() if ctx is
+	// done before every request finishes, without waiting any longer.
+	// Calling Shutdown on an already stopped server does nothing.
+	Shutdown(ctx context.Context) error
+	// Address returns the address the server is listening on, including
+	// its scheme (https:// when TLS is enabled).
+	Address() string
+}
+
+// route pairs a registered Handler with its pre-compiled path pattern, so
+// incoming requests are matched without re-parsing Binding.Path.
+type route struct {
+	handler Handler
+	binding Binding
+	pattern compiledPattern
+}
+
+type server struct {
+	host string
+	port int
+
+	tlsConfig *tls.Config
+	tlsErr    error
+
+	listener   net.Listener
+	httpServer *http.Server
+	wg         sync.WaitGroup
+
+	routes        []route
+	authenticator Authenticator
+	middleware    []Middleware
+
+	methodNotAllowed bool
+	maxBodyBytes     int64
+
+	started bool
+}
+
+// NewServer returns a Server that will listen on host:port once Start is
+// called.
+func NewServer(host string, port int, opts ...Option) Server {
+	s := &server{host: host, port: port}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *server) Register(h Handler) {
+	b := h.Binding()
+	s.routes = append(s.routes, route{handler: h, binding: b, pattern: compilePattern(b.Path)})
+}
+
+func (s *server) SetAuthenticator(a Authenticator) {
+	s.authenticator = a
+}
+
+func (s *server) Use(mw Middleware) error {
+	if s.started {
+		return errors.New("server: cannot add middleware after the server has started")
+	}
+	s.middleware = append(s.middleware, mw)
+	return nil
+}
+
+func (s *server) Start() error {
+	if s.started {
+		return nil
+	}
+	if s.tlsErr != nil {
+		return s.tlsErr
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", s.host, s.port))
+	if err != nil {
+		return err
+	}
+	if s.tlsConfig != nil {
+		listener = tls.NewListener(listener, s.tlsConfig)
+	}
+
+	s.listener = listener
+	s.started = true
+
+	if s.httpServer == nil {
+		s.httpServer = &http.Server{}
+	}
+	s.httpServer.Handler = http.HandlerFunc(s.serveHTTP)
+
+	go s.httpServer.Serve(s.listener)
+	return nil
+}
+
+func (s *server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultShutdownTimeout)
+	defer cancel()
+	return s.Shutdown(ctx)
+}
+
+func (s *server) Shutdown(ctx context.Context) error {
+	if !s.started {
+		return nil
+	}
+	s.started = false
+
+	shutdownDone := make(chan error, 1)
+	go func() {
+		shutdownDone <- s.httpServer.Shutdown(ctx)
+	}()
+
+	drained := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return <-shutdownDone
+	case <-ctx.Done():
+		// The deadline won before every in-flight handler finished; stop
+		// waiting on them and forcibly tear down the listener and any
+		// open connections instead of leaving a wedged handler to run
+		// forever.
+		s.httpServer.Close()
+		return ctx.Err()
+	}
+}
+
+func (s *server) Address() string {
+	scheme := "http"
+	if s.tlsConfig != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, s.listener.Addr().String())
+}
+
+func (s *server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.wg.Add(1)
+	defer s.wg.Done()
+
+	if s.maxBodyBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, s.maxBodyBytes)
+	}
+
+	var principal Principal
+	if s.authenticator != nil {
+		authReq := newRequest(r, nil, nil)
+		authResp := newResponse(w)
+		authenticated := false
+		AuthenticationMiddleware(s.authenticator)(func(Request, Response) {
+			authenticated = true
+		})(authReq, authResp)
+		if !authenticated {
+			s.flush(w, authResp)
+			return
+		}
+		principal = authReq.Principal()
+	}
+
+	handler, pathValues, allowedMethods, ok := s.match(r.Method, r.URL.Path)
+	if !ok {
+		if s.methodNotAllowed && len(allowedMethods) > 0 {
+			w.Header().Set("Allow", strings.Join(allowedMethods, ", "))
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	req := newRequest(r, principal, pathValues)
+	resp := newResponse(w)
+
+	chain(handler.Handle, s.middleware)(req, resp)
+
+	s.flush(w, resp)
+}
+
+// flush writes resp's buffered status code, headers, and body to w, unless
+// they were already sent via Response.SetStreaming or BodyWriter.
+func (s *server) flush(w http.ResponseWriter, resp *response) {
+	if resp.headerSent {
+		return
+	}
+	for key, values := range resp.header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(resp.statusCode)
+	n, _ := w.Write(resp.body)
+	resp.written = n
+}
+
+// routeGroup collects every route registered under the same raw path
+// pattern, keyed by method, so a method mismatch can report the full set
+// of methods the path supports.
+type routeGroup struct {
+	rank     []int
+	params   map[string]string
+	byMethod map[string]Handler
+}
+
+// match finds the handler for method and path. Among every registered
+// pattern that matches path, it picks the most specific one that actually
+// has method registered, falling back to successively less specific
+// patterns rather than committing to the single best match regardless of
+// method. If some pattern matches path but none of them has method, it
+// reports the methods registered for the most specific one (for a 405
+// Method Not Allowed response); if no pattern matches path at all, it
+// reports no match (404 Not Found).
+func (s *server) match(method, path string) (Handler, map[string]string, []string, bool) {
+	groups := make(map[string]*routeGroup)
+	var order []string
+
+	for _, rt := range s.routes {
+		params, ok := rt.pattern.match(path)
+		if !ok {
+			continue
+		}
+
+		g, exists := groups[rt.binding.Path]
+		if !exists {
+			g = &routeGroup{rank: rt.pattern.specificity(), params: params, byMethod: map[string]Handler{}}
+			groups[rt.binding.Path] = g
+			order = append(order, rt.binding.Path)
+		}
+		g.byMethod[rt.binding.Method] = rt.handler
+	}
+
+	if len(order) == 0 {
+		return nil, nil, nil, false
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		return moreSpecific(groups[order[i]].rank, groups[order[j]].rank)
+	})
+
+	for _, p := range order {
+		g := groups[p]
+		if handler, ok := g.byMethod[method]; ok {
+			return handler, g.params, nil, true
+		}
+	}
+
+	best := groups[order[0]]
+	methods := make([]string, 0, len(best.byMethod))
+	for m := range best.byMethod {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return nil, nil, methods, false
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"time"
+)
+
+// ServerOptions configures a Server's listener beyond the basics NewServer
+// accepts: TLS, mutual TLS, timeouts, and request size limits.
+type ServerOptions struct {
+	Host string
+	Port int
+
+	// CertFile and KeyFile, if both set, make the server listen over TLS.
+	CertFile string
+	KeyFile  string
+
+	// ClientCAs, if set, makes the server request and verify a client
+	// certificate against this pool, enabling mutual TLS. ClientAuth
+	// controls how strictly; it defaults to
+	// tls.RequireAndVerifyClientCert when ClientCAs is set and ClientAuth
+	// is left at its zero value.
+	ClientCAs  *x509.CertPool
+	ClientAuth tls.ClientAuthType
+
+	// ReadTimeout, WriteTimeout and IdleTimeout mirror the fields of the
+	// same name on http.Server. Zero means no timeout.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+
+	// MaxHeaderBytes mirrors http.Server.MaxHeaderBytes. Zero means the
+	// http.Server default.
+	MaxHeaderBytes int
+
+	// MaxBodyBytes limits the size of a request body. Zero means
+	// unlimited. Exceeding it fails the read with an error.
+	MaxBodyBytes int64
+
+	// MethodNotAllowed enables the same behavior as the WithMethodNotAllowed
+	// Option: 405 Method Not Allowed with an Allow header instead of 404
+	// Not Found when a path matches but the method does not.
+	MethodNotAllowed bool
+}
+
+// NewServerWithOptions returns a Server configured by opts. Use it instead
+// of NewServer when you need TLS, mutual TLS, timeouts, or a request body
+// size limit.
+func NewServerWithOptions(opts ServerOptions) Server {
+	s := &server{
+		host:             opts.Host,
+		port:             opts.Port,
+		methodNotAllowed: opts.MethodNotAllowed,
+		maxBodyBytes:     opts.MaxBodyBytes,
+	}
+
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			s.tlsErr = err
+		} else {
+			s.tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+		}
+	}
+
+	if opts.ClientCAs != nil {
+		if s.tlsConfig == nil {
+			s.tlsConfig = &tls.Config{}
+		}
+		s.tlsConfig.ClientCAs = opts.ClientCAs
+		s.tlsConfig.ClientAuth = opts.ClientAuth
+		if s.tlsConfig.ClientAuth == tls.NoClientCert {
+			s.tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	s.httpServer = &http.Server{
+		ReadTimeout:    opts.ReadTimeout,
+		WriteTimeout:   opts.WriteTimeout,
+		IdleTimeout:    opts.IdleTimeout,
+		MaxHeaderBytes: opts.MaxHeaderBytes,
+	}
+
+	return s
+}
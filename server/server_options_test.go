@@ -0,0 +1,85 @@
+package server_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/Bo0mer/os-agent/server"
+	"github.com/Bo0mer/os-agent/server/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServerOptions", func() {
+
+	var server Server
+
+	AfterEach(func() {
+		if server != nil {
+			server.Stop()
+		}
+	})
+
+	Describe("MaxBodyBytes", func() {
+		BeforeEach(func() {
+			server = NewServerWithOptions(ServerOptions{
+				Host:         "127.0.0.1",
+				Port:         0,
+				MaxBodyBytes: 8,
+			})
+
+			handler := new(fakes.FakeHandler)
+			handler.BindingReturns(Binding{Method: "POST", Path: "/upload"})
+			handler.HandleStub = func(req Request, resp Response) {
+				if _, err := io.Copy(io.Discard, req.BodyReader()); err != nil {
+					resp.SetStatusCode(http.StatusRequestEntityTooLarge)
+					return
+				}
+				resp.SetStatusCode(http.StatusOK)
+			}
+			server.Register(handler)
+			Expect(server.Start()).To(Succeed())
+		})
+
+		It("rejects a body larger than the limit", func() {
+			resp, err := http.Post(server.Address()+"/upload", "application/octet-stream", bytes.NewBufferString("this body is far longer than the limit"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+		})
+
+		It("allows a body within the limit", func() {
+			resp, err := http.Post(server.Address()+"/upload", "application/octet-stream", bytes.NewBufferString("short"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+	})
+
+	Describe("WriteTimeout", func() {
+		BeforeEach(func() {
+			server = NewServerWithOptions(ServerOptions{
+				Host:         "127.0.0.1",
+				Port:         0,
+				WriteTimeout: 50 * time.Millisecond,
+			})
+
+			handler := new(fakes.FakeHandler)
+			handler.BindingReturns(Binding{Method: "GET", Path: "/slow"})
+			handler.HandleStub = func(req Request, resp Response) {
+				time.Sleep(200 * time.Millisecond)
+				resp.SetStatusCode(http.StatusOK)
+			}
+			server.Register(handler)
+			Expect(server.Start()).To(Succeed())
+		})
+
+		It("fails the request when the slow handler's response misses the deadline", func() {
+			client := &http.Client{Timeout: time.Second}
+
+			_, err := client.Get(server.Address() + "/slow")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
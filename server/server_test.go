@@ -28,17 +28,17 @@ var _ = Describe("Server", func() {
 	}
 
 	var doPost = func(path string, bodyType string, body []byte) ([]byte, int, error) {
-		url := fmt.Sprintf("http://%s%s", server.Address(), path)
+		url := fmt.Sprintf("%s%s", server.Address(), path)
 		return doAction(http.Post(url, bodyType, bytes.NewBuffer(body)))
 	}
 
 	var doGet = func(path string) ([]byte, int, error) {
-		url := fmt.Sprintf("http://%s%s", server.Address(), path)
+		url := fmt.Sprintf("%s%s", server.Address(), path)
 		return doAction(http.Get(url))
 	}
 
 	var doPostWithAuthentication = func(path, username, password, bodyType string, body []byte) ([]byte, int, error) {
-		url := fmt.Sprintf("http://%s%s", server.Address(), path)
+		url := fmt.Sprintf("%s%s", server.Address(), path)
 
 		request, _ := http.NewRequest("POST", url, bytes.NewBuffer(body))
 		request.SetBasicAuth(username, password)
@@ -0,0 +1,131 @@
+package server_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/Bo0mer/os-agent/server"
+	"github.com/Bo0mer/os-agent/server/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Graceful shutdown", func() {
+
+	var server Server
+
+	BeforeEach(func() {
+		server = NewServer("127.0.0.1", 0)
+	})
+
+	It("returns promptly when the server is idle", func() {
+		Expect(server.Start()).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		done := make(chan error, 1)
+		go func() { done <- server.Shutdown(ctx) }()
+
+		Eventually(done, 200*time.Millisecond).Should(Receive(BeNil()))
+	})
+
+	It("blocks until a slow handler finishes", func() {
+		handlerStarted := make(chan struct{})
+		releaseHandler := make(chan struct{})
+
+		handler := new(fakes.FakeHandler)
+		handler.BindingReturns(Binding{Method: "GET", Path: "/slow"})
+		handler.HandleStub = func(req Request, resp Response) {
+			close(handlerStarted)
+			<-releaseHandler
+			resp.SetStatusCode(http.StatusOK)
+		}
+		server.Register(handler)
+		Expect(server.Start()).To(Succeed())
+
+		go http.Get(address(server) + "/slow")
+		<-handlerStarted
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		done := make(chan error, 1)
+		go func() { done <- server.Shutdown(ctx) }()
+
+		Consistently(done, 100*time.Millisecond).ShouldNot(Receive())
+
+		close(releaseHandler)
+		Eventually(done, time.Second).Should(Receive(BeNil()))
+	})
+
+	It("returns context.DeadlineExceeded when the deadline fires before the handler completes", func() {
+		handlerStarted := make(chan struct{})
+		releaseHandler := make(chan struct{})
+		defer close(releaseHandler)
+
+		handler := new(fakes.FakeHandler)
+		handler.BindingReturns(Binding{Method: "GET", Path: "/slow"})
+		handler.HandleStub = func(req Request, resp Response) {
+			close(handlerStarted)
+			<-releaseHandler
+			resp.SetStatusCode(http.StatusOK)
+		}
+		server.Register(handler)
+		Expect(server.Start()).To(Succeed())
+
+		go http.Get(address(server) + "/slow")
+		<-handlerStarted
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := server.Shutdown(ctx)
+		Expect(err).To(Equal(context.DeadlineExceeded))
+	})
+
+	It("forcibly tears down the listener and in-flight connections once the deadline passes", func() {
+		handlerStarted := make(chan struct{})
+		releaseHandler := make(chan struct{})
+		defer close(releaseHandler)
+
+		handler := new(fakes.FakeHandler)
+		handler.BindingReturns(Binding{Method: "GET", Path: "/slow"})
+		handler.HandleStub = func(req Request, resp Response) {
+			close(handlerStarted)
+			<-releaseHandler
+			resp.SetStatusCode(http.StatusOK)
+		}
+		server.Register(handler)
+		Expect(server.Start()).To(Succeed())
+
+		addr := address(server)
+		clientDone := make(chan error, 1)
+		go func() {
+			_, err := http.Get(addr + "/slow")
+			clientDone <- err
+		}()
+		<-handlerStarted
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := server.Shutdown(ctx)
+		Expect(err).To(Equal(context.DeadlineExceeded))
+
+		// The wedged in-flight connection should be torn down rather
+		// than left to finish whenever the handler feels like it.
+		Eventually(clientDone, time.Second).Should(Receive(HaveOccurred()))
+
+		// And the listener itself should no longer accept connections.
+		conn, dialErr := net.Dial("tcp", strings.TrimPrefix(addr, "http://"))
+		if dialErr == nil {
+			conn.Close()
+		}
+		Expect(dialErr).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,128 @@
+package server_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	. "github.com/Bo0mer/os-agent/server"
+	"github.com/Bo0mer/os-agent/server/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Streaming bodies", func() {
+
+	var server Server
+
+	BeforeEach(func() {
+		server = NewServer("127.0.0.1", 0)
+	})
+
+	AfterEach(func() {
+		server.Stop()
+	})
+
+	It("still buffers the body for Body/SetBody", func() {
+		handler := new(fakes.FakeHandler)
+		handler.BindingReturns(Binding{Method: "POST", Path: "/echo"})
+		handler.HandleStub = func(req Request, resp Response) {
+			resp.SetStatusCode(http.StatusOK)
+			resp.SetBody(req.Body())
+		}
+		server.Register(handler)
+		Expect(server.Start()).To(Succeed())
+
+		resp, err := http.Post(address(server)+"/echo", "text/plain", bytes.NewBufferString("hello"))
+		Expect(err).ToNot(HaveOccurred())
+		body, _ := readAll(resp)
+		Expect(string(body)).To(Equal("hello"))
+	})
+
+	It("lets a handler stream the request body straight to the response via BodyReader/BodyWriter", func() {
+		handler := new(fakes.FakeHandler)
+		handler.BindingReturns(Binding{Method: "POST", Path: "/stream"})
+		handler.HandleStub = func(req Request, resp Response) {
+			resp.SetStreaming(true)
+			w := resp.BodyWriter()
+			defer w.Close()
+			io.Copy(w, req.BodyReader())
+		}
+		server.Register(handler)
+		Expect(server.Start()).To(Succeed())
+
+		resp, err := http.Post(address(server)+"/stream", "application/octet-stream", bytes.NewBufferString("streamed payload"))
+		Expect(err).ToNot(HaveOccurred())
+		body, _ := readAll(resp)
+		Expect(string(body)).To(Equal("streamed payload"))
+	})
+
+	It("flushes headers set before streaming and ignores ones set after", func() {
+		handler := new(fakes.FakeHandler)
+		handler.BindingReturns(Binding{Method: "GET", Path: "/stream"})
+		handler.HandleStub = func(req Request, resp Response) {
+			resp.SetHeader("X-Before", "kept")
+			resp.SetStatusCode(http.StatusAccepted)
+			resp.SetStreaming(true)
+			resp.SetHeader("X-After", "dropped")
+			w := resp.BodyWriter()
+			w.Write([]byte("ok"))
+			w.Close()
+		}
+		server.Register(handler)
+		Expect(server.Start()).To(Succeed())
+
+		resp, err := http.Get(address(server) + "/stream")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+		Expect(resp.Header.Get("X-Before")).To(Equal("kept"))
+		Expect(resp.Header.Get("X-After")).To(BeEmpty())
+	})
+
+	Describe("MultipartHandler", func() {
+		It("iterates every part without buffering the whole body", func() {
+			var parts []string
+
+			handler := MultipartHandler(Binding{Method: "POST", Path: "/upload"}, func(part *multipart.Part, req Request, resp Response) error {
+				content, err := io.ReadAll(part)
+				if err != nil {
+					return err
+				}
+				parts = append(parts, part.FormName()+"="+string(content))
+				return nil
+			})
+			server.Register(handler)
+			Expect(server.Start()).To(Succeed())
+
+			var buf bytes.Buffer
+			mw := multipart.NewWriter(&buf)
+			fw, _ := mw.CreateFormField("name")
+			fw.Write([]byte("ivan"))
+			fw2, _ := mw.CreateFormField("role")
+			fw2.Write([]byte("agent"))
+			mw.Close()
+
+			req, _ := http.NewRequest("POST", address(server)+"/upload", &buf)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(parts).To(ConsistOf("name=ivan", "role=agent"))
+		})
+
+		It("returns 400 for a non-multipart request", func() {
+			handler := MultipartHandler(Binding{Method: "POST", Path: "/upload"}, func(part *multipart.Part, req Request, resp Response) error {
+				return nil
+			})
+			server.Register(handler)
+			Expect(server.Start()).To(Succeed())
+
+			resp, err := http.Post(address(server)+"/upload", "text/plain", bytes.NewBufferString("not multipart"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
@@ -0,0 +1,245 @@
+package server_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/Bo0mer/os-agent/server"
+	"github.com/Bo0mer/os-agent/server/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testCA is a throwaway certificate authority used to mint server and
+// client certificates for the TLS/mTLS specs below.
+type testCA struct {
+	cert    *x509.Certificate
+	certDER []byte
+	key     *rsa.PrivateKey
+}
+
+func newTestCA() *testCA {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).ToNot(HaveOccurred())
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+
+	return &testCA{cert: cert, certDER: der, key: key}
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// issue mints a certificate for commonName, signed by ca, valid for the
+// given lifetime (negative to produce an already-expired certificate).
+func (ca *testCA) issue(commonName string, lifetime time.Duration, extKeyUsage []x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).ToNot(HaveOccurred())
+
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := time.Now().Add(lifetime)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  extKeyUsage,
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	Expect(err).ToNot(HaveOccurred())
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM
+}
+
+func writeTempFile(dir, name string, data []byte) string {
+	path := filepath.Join(dir, name)
+	Expect(ioutil.WriteFile(path, data, 0600)).To(Succeed())
+	return path
+}
+
+var _ = Describe("TLS and mutual TLS", func() {
+
+	var ca *testCA
+	var dir string
+	var server Server
+
+	BeforeEach(func() {
+		ca = newTestCA()
+
+		var err error
+		dir, err = ioutil.TempDir("", "os-agent-tls")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if server != nil {
+			server.Stop()
+		}
+		os.RemoveAll(dir)
+	})
+
+	registerOKHandler := func(server Server) *fakes.FakeHandler {
+		handler := new(fakes.FakeHandler)
+		handler.BindingReturns(Binding{Method: "GET", Path: "/secure"})
+		handler.HandleStub = func(req Request, resp Response) {
+			resp.SetStatusCode(http.StatusOK)
+		}
+		server.Register(handler)
+		return handler
+	}
+
+	Context("plain TLS", func() {
+		var certFile, keyFile string
+
+		BeforeEach(func() {
+			certPEM, keyPEM := ca.issue("127.0.0.1", time.Hour, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth})
+			certFile = writeTempFile(dir, "server.crt", certPEM)
+			keyFile = writeTempFile(dir, "server.key", keyPEM)
+
+			server = NewServerWithOptions(ServerOptions{
+				Host:     "127.0.0.1",
+				Port:     0,
+				CertFile: certFile,
+				KeyFile:  keyFile,
+			})
+			registerOKHandler(server)
+			Expect(server.Start()).To(Succeed())
+		})
+
+		It("reports an https:// address", func() {
+			Expect(server.Address()).To(HavePrefix("https://"))
+		})
+
+		It("serves requests from a client that trusts the CA", func() {
+			client := &http.Client{Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: ca.pool()},
+			}}
+
+			resp, err := client.Get(server.Address() + "/secure")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("rejects a client that does not trust the issuing CA", func() {
+			client := &http.Client{Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: x509.NewCertPool()},
+			}}
+
+			_, err := client.Get(server.Address() + "/secure")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects an expired server certificate", func() {
+			expiredCertPEM, expiredKeyPEM := ca.issue("127.0.0.1", -time.Minute, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth})
+			expiredServer := NewServerWithOptions(ServerOptions{
+				Host:     "127.0.0.1",
+				Port:     0,
+				CertFile: writeTempFile(dir, "expired.crt", expiredCertPEM),
+				KeyFile:  writeTempFile(dir, "expired.key", expiredKeyPEM),
+			})
+			registerOKHandler(expiredServer)
+			Expect(expiredServer.Start()).To(Succeed())
+			defer expiredServer.Stop()
+
+			client := &http.Client{Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: ca.pool()},
+			}}
+
+			_, err := client.Get(expiredServer.Address() + "/secure")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("mutual TLS", func() {
+		var certFile, keyFile string
+
+		BeforeEach(func() {
+			certPEM, keyPEM := ca.issue("127.0.0.1", time.Hour, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth})
+			certFile = writeTempFile(dir, "server.crt", certPEM)
+			keyFile = writeTempFile(dir, "server.key", keyPEM)
+
+			server = NewServerWithOptions(ServerOptions{
+				Host:      "127.0.0.1",
+				Port:      0,
+				CertFile:  certFile,
+				KeyFile:   keyFile,
+				ClientCAs: ca.pool(),
+			})
+			server.SetAuthenticator(NewCertAuthenticator())
+		})
+
+		It("allows a client presenting a certificate signed by a trusted CA and exposes its subject as the principal", func() {
+			handler := new(fakes.FakeHandler)
+			handler.BindingReturns(Binding{Method: "GET", Path: "/secure"})
+			handler.HandleStub = func(req Request, resp Response) {
+				Expect(req.Principal()).ToNot(BeNil())
+				Expect(req.Principal().Name()).To(Equal("ivan"))
+				resp.SetStatusCode(http.StatusOK)
+			}
+			server.Register(handler)
+			Expect(server.Start()).To(Succeed())
+
+			clientCertPEM, clientKeyPEM := ca.issue("ivan", time.Hour, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth})
+			clientCert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+			Expect(err).ToNot(HaveOccurred())
+
+			client := &http.Client{Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:      ca.pool(),
+					Certificates: []tls.Certificate{clientCert},
+				},
+			}}
+
+			resp, err := client.Get(server.Address() + "/secure")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("rejects a client presenting no certificate", func() {
+			registerOKHandler(server)
+			Expect(server.Start()).To(Succeed())
+
+			client := &http.Client{Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: ca.pool()},
+			}}
+
+			_, err := client.Get(server.Address() + "/secure")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})